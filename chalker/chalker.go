@@ -0,0 +1,43 @@
+// Package chalker provides simple, colorized logging helpers for the CLI
+package chalker
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ttacon/chalk"
+)
+
+// Level is the severity of a logged message
+type Level string
+
+// Supported log levels
+const (
+	DEFAULT Level = "default"
+	ERROR   Level = "error"
+	INFO    Level = "info"
+	SUCCESS Level = "success"
+	WARN    Level = "warn"
+)
+
+// Log prints a message to stdout, colorized according to its level
+func Log(level Level, message string) {
+	switch level {
+	case ERROR:
+		fmt.Println(chalk.Red.Color("ERROR: " + message))
+	case WARN:
+		fmt.Println(chalk.Yellow.Color("WARN: " + message))
+	case SUCCESS:
+		fmt.Println(chalk.Green.Color(message))
+	case INFO:
+		fmt.Println(chalk.Cyan.Color(message))
+	default:
+		fmt.Println(message)
+	}
+}
+
+// Error returns a plain error for the given message, logging it as an ERROR first
+func Error(message string) error {
+	Log(ERROR, message)
+	return errors.New(message)
+}
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/paymail-inspector/chalker"
+	"github.com/mrz1836/paymail-inspector/paymail"
+	"github.com/spf13/cobra"
+	"github.com/ttacon/chalk"
+)
+
+// contactCmd represents the parent contact command
+var contactCmd = &cobra.Command{
+	Use:     "contact",
+	Short:   "Manage locally stored paymail contacts",
+	Aliases: []string{"contacts"},
+	Long: chalk.Yellow.Color(`
+Add, list, remove and verify paymail contacts, built on top of the PKI and
+public profile capabilities. Contacts are persisted locally so a pubkey
+rotation can be detected (trust-on-first-use) the next time you verify.`),
+}
+
+// contactStorePath returns the path to the local contact store
+func contactStorePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".paymail-inspector", "contacts.json"), nil
+}
+
+// fetchContact fetches the capabilities, PKI and (optionally) public profile for
+// a paymail address and returns it as a Contact, ready to be stored.
+func fetchContact(paymailAddress, domain string) (*paymail.Contact, error) {
+	capabilities, err := getCapabilities(domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get capabilities: %w", err)
+	}
+
+	pkiUrl := capabilities.GetValueString(paymail.BRFCPki, paymail.BRFCPkiAlternate)
+	if len(pkiUrl) == 0 {
+		return nil, fmt.Errorf("%s is missing a required capability: %s", domain, paymail.BRFCPki)
+	}
+
+	parts := strings.Split(paymailAddress, "@")
+
+	pki, err := getPki(pkiUrl, parts[0], domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pki: %w", err)
+	} else if pki == nil {
+		return nil, fmt.Errorf("missing pki response for: %s", paymailAddress)
+	}
+
+	contact := &paymail.Contact{
+		Paymail: paymailAddress,
+		PubKey:  pki.PubKey,
+		AddedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if profileUrl := capabilities.GetValueString(paymail.BRFCPublicProfile, ""); len(profileUrl) > 0 {
+		if profile, profileErr := paymail.GetPublicProfile(profileUrl, parts[0], domain); profileErr == nil && profile != nil {
+			contact.Name = profile.Name
+			contact.Avatar = profile.Avatar
+		}
+	}
+
+	return contact, nil
+}
+
+// contactAddCmd adds a new contact
+var contactAddCmd = &cobra.Command{
+	Use:     "add",
+	Short:   "Add a paymail contact",
+	Example: configDefault + " contact add this@address.com",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return chalker.Error("contact add requires exactly one paymail address")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		domain, paymailAddress := paymail.ExtractParts(args[0])
+		if len(paymailAddress) == 0 {
+			chalker.Log(chalker.ERROR, "paymail address not found or invalid")
+			return
+		} else if ok := validatePaymailAndDomain(paymailAddress, domain); !ok {
+			return
+		}
+
+		contact, err := fetchContact(paymailAddress, domain)
+		if err != nil {
+			chalker.Log(chalker.ERROR, err.Error())
+			return
+		}
+
+		storePath, err := contactStorePath()
+		if err != nil {
+			chalker.Log(chalker.ERROR, err.Error())
+			return
+		}
+
+		store, err := paymail.LoadContactStore(storePath)
+		if err != nil {
+			chalker.Log(chalker.ERROR, err.Error())
+			return
+		}
+
+		store.Add(contact)
+		if err = store.Save(); err != nil {
+			chalker.Log(chalker.ERROR, err.Error())
+			return
+		}
+
+		chalker.Log(chalker.SUCCESS, fmt.Sprintf("added contact: %s (pubkey: %s)", chalk.Cyan.Color(paymailAddress), contact.PubKey))
+	},
+}
+
+// contactListCmd lists all known contacts
+var contactListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List all known paymail contacts",
+	Example: configDefault + " contact list",
+	Run: func(cmd *cobra.Command, args []string) {
+		storePath, err := contactStorePath()
+		if err != nil {
+			chalker.Log(chalker.ERROR, err.Error())
+			return
+		}
+
+		store, err := paymail.LoadContactStore(storePath)
+		if err != nil {
+			chalker.Log(chalker.ERROR, err.Error())
+			return
+		}
+
+		contacts := store.List()
+		if len(contacts) == 0 {
+			chalker.Log(chalker.DEFAULT, "no contacts found")
+			return
+		}
+
+		for _, contact := range contacts {
+			chalker.Log(chalker.DEFAULT, fmt.Sprintf("%s - pubkey: %s", chalk.Cyan.Color(contact.Paymail), contact.PubKey))
+		}
+	},
+}
+
+// contactRemoveCmd removes a contact
+var contactRemoveCmd = &cobra.Command{
+	Use:     "remove",
+	Short:   "Remove a paymail contact",
+	Aliases: []string{"rm", "delete"},
+	Example: configDefault + " contact remove this@address.com",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return chalker.Error("contact remove requires exactly one paymail address")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		_, paymailAddress := paymail.ExtractParts(args[0])
+		if len(paymailAddress) == 0 {
+			chalker.Log(chalker.ERROR, "paymail address not found or invalid")
+			return
+		}
+
+		storePath, err := contactStorePath()
+		if err != nil {
+			chalker.Log(chalker.ERROR, err.Error())
+			return
+		}
+
+		store, err := paymail.LoadContactStore(storePath)
+		if err != nil {
+			chalker.Log(chalker.ERROR, err.Error())
+			return
+		}
+
+		if !store.Remove(paymailAddress) {
+			chalker.Log(chalker.WARN, fmt.Sprintf("contact not found: %s", paymailAddress))
+			return
+		}
+
+		if err = store.Save(); err != nil {
+			chalker.Log(chalker.ERROR, err.Error())
+			return
+		}
+
+		chalker.Log(chalker.SUCCESS, fmt.Sprintf("removed contact: %s", paymailAddress))
+	},
+}
+
+// contactVerifyCmd re-fetches a contact's PKI and warns on pubkey rotation
+var contactVerifyCmd = &cobra.Command{
+	Use:     "verify",
+	Short:   "Re-fetch a contact's PKI and warn on pubkey rotation",
+	Example: configDefault + " contact verify this@address.com",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return chalker.Error("contact verify requires exactly one paymail address")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		domain, paymailAddress := paymail.ExtractParts(args[0])
+		if len(paymailAddress) == 0 {
+			chalker.Log(chalker.ERROR, "paymail address not found or invalid")
+			return
+		}
+
+		storePath, err := contactStorePath()
+		if err != nil {
+			chalker.Log(chalker.ERROR, err.Error())
+			return
+		}
+
+		store, err := paymail.LoadContactStore(storePath)
+		if err != nil {
+			chalker.Log(chalker.ERROR, err.Error())
+			return
+		}
+
+		existing, known := store.Get(paymailAddress)
+		if !known {
+			chalker.Log(chalker.ERROR, fmt.Sprintf("contact not found: %s - run `contact add` first", paymailAddress))
+			return
+		}
+
+		current, err := fetchContact(paymailAddress, domain)
+		if err != nil {
+			chalker.Log(chalker.ERROR, err.Error())
+			return
+		}
+
+		if current.PubKey != existing.PubKey {
+			chalker.Log(chalker.WARN, fmt.Sprintf(
+				"pubkey ROTATION detected for %s - stored: %s, current: %s - run `contact add` again to trust the new key",
+				paymailAddress, existing.PubKey, current.PubKey,
+			))
+			return
+		}
+
+		chalker.Log(chalker.SUCCESS, fmt.Sprintf("pubkey unchanged for: %s", paymailAddress))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(contactCmd)
+	contactCmd.AddCommand(contactAddCmd)
+	contactCmd.AddCommand(contactListCmd)
+	contactCmd.AddCommand(contactRemoveCmd)
+	contactCmd.AddCommand(contactVerifyCmd)
+}
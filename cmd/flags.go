@@ -0,0 +1,16 @@
+package cmd
+
+// Flag/viper key names shared across commands
+const (
+	flagSenderHandle = "sender-handle"
+	flagSenderName   = "sender-name"
+)
+
+// Flags of the resolve command (skipPki is also read by getPki)
+var (
+	amount            uint64
+	purpose           string
+	signature         string
+	skipPki           bool
+	skipPublicProfile bool
+)
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/paymail-inspector/chalker"
+	"github.com/mrz1836/paymail-inspector/paymail"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/ttacon/chalk"
+)
+
+// Flags for the p2p command
+var (
+	satoshis     uint64
+	txHex        string
+	txFile       string
+	p2pNote      string
+	p2pSender    string
+	p2pPubKey    string
+	p2pSignature string
+)
+
+// p2pCmd represents the p2p command
+var p2pCmd = &cobra.Command{
+	Use:   "p2p",
+	Short: "Resolves a paymail address via p2p",
+	Long: chalk.Green.Color(`
+                 _____
+_____     ____  |__  |_____ ______
+\__  \   /    \  /   /\____ \\____ \
+ / __ \_|   |  \/    \|  |_> >  |_> >
+(____  /|___|  /\_____ \   __/|   __/
+     \/      \/       \/__|   |__|   `) + `
+` + chalk.Yellow.Color(`
+Requests a P2P Payment Destination from a receiver's paymail provider, then submits a
+raw transaction paying those outputs using the P2P Transaction capability.
+
+Falls back to basic address resolution if the provider does not advertise the P2P
+capabilities.
+
+Read more at: `+chalk.Cyan.Color("https://docs.moneybutton.com/docs/paymail-07-p2p-payment-destination.html")),
+	Aliases:    []string{"p2ptx", "p2p-transaction"},
+	SuggestFor: []string{"p2p-payment-destination"},
+	Example:    configDefault + " p2p this@address.com --satoshis 1000 --tx-hex 0100...",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return chalker.Error("p2p requires a paymail address")
+		} else if len(args) > 1 {
+			return chalker.Error("p2p only supports one address at a time")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+		// Extract the parts given
+		domain, paymailAddress := paymail.ExtractParts(args[0])
+
+		// Did we get a paymail address?
+		if len(paymailAddress) == 0 {
+			chalker.Log(chalker.ERROR, "paymail address not found or invalid")
+			return
+		}
+
+		// Validate the paymail address and domain (error already shown)
+		if ok := validatePaymailAndDomain(paymailAddress, domain); !ok {
+			return
+		}
+
+		// Get the capabilities
+		capabilities, err := getCapabilities(domain)
+		if err != nil {
+			chalker.Log(chalker.ERROR, fmt.Sprintf("error: %s", err.Error()))
+			return
+		}
+
+		// Get the alias of the address
+		parts := strings.Split(paymailAddress, "@")
+
+		// Does this provider support the p2p capabilities? Fall back to basic resolution if not.
+		destinationURL := capabilities.GetValueString(paymail.BRFCP2PPaymentDestination, "")
+		transactionURL := capabilities.GetValueString(paymail.BRFCP2PTransaction, "")
+		if len(destinationURL) == 0 || len(transactionURL) == 0 {
+			chalker.Log(chalker.WARN, fmt.Sprintf("%s does not support p2p, falling back to: %s", domain, paymail.BRFCBasicAddressResolution))
+
+			resolveUrl := capabilities.GetValueString(paymail.BRFCPaymentDestination, paymail.BRFCBasicAddressResolution)
+			if len(resolveUrl) == 0 {
+				chalker.Log(chalker.ERROR, fmt.Sprintf("%s is missing a required capability: %s", domain, paymail.BRFCPaymentDestination))
+				return
+			}
+
+			// No sender handle given? (default: set to the receiver's paymail address)
+			// Read the flag directly: resolve binds the same viper key to its own flag
+			senderFlag, _ := cmd.PersistentFlags().GetString(flagSenderHandle)
+			_, senderHandle := paymail.ExtractParts(senderFlag)
+			if len(senderHandle) == 0 {
+				chalker.Log(chalker.WARN, fmt.Sprintf("--%s not set, using: %s", flagSenderHandle, paymailAddress))
+				senderHandle = paymailAddress
+			}
+
+			var resolutionResponse *paymail.AddressResolutionResponse
+			if resolutionResponse, err = paymail.AddressResolution(resolveUrl, parts[0], domain, &paymail.AddressResolutionRequest{
+				Amount:       satoshis,
+				Dt:           time.Now().UTC().Format(time.RFC3339), // UTC is assumed
+				SenderHandle: senderHandle,
+			}); err != nil {
+				chalker.Log(chalker.ERROR, fmt.Sprintf("address resolution failed: %s", err.Error()))
+				return
+			}
+
+			chalker.Log(chalker.SUCCESS, "address resolution successful")
+			chalker.Log(chalker.DEFAULT, fmt.Sprintf("output script: %s", chalk.Cyan.Color(resolutionResponse.Output)))
+			return
+		}
+
+		// Request a payment destination
+		chalker.Log(chalker.DEFAULT, fmt.Sprintf("requesting p2p payment destination for: %s...", chalk.Cyan.Color(paymailAddress)))
+
+		var destination *paymail.P2PPaymentDestinationResponse
+		if destination, err = paymail.GetP2PPaymentDestination(destinationURL, parts[0], domain, satoshis); err != nil {
+			chalker.Log(chalker.ERROR, fmt.Sprintf("p2p payment destination request failed: %s", err.Error()))
+			return
+		}
+
+		chalker.Log(chalker.SUCCESS, fmt.Sprintf("received %d output(s), reference: %s", len(destination.Outputs), destination.Reference))
+		for _, output := range destination.Outputs {
+			chalker.Log(chalker.DEFAULT, fmt.Sprintf("output script: %s", chalk.Cyan.Color(output.Script)))
+		}
+
+		// No raw tx given? We can't complete the p2p flow any further
+		if len(txHex) == 0 && len(txFile) == 0 {
+			chalker.Log(chalker.WARN, "no --tx-hex or --tx-file given, skipping transaction submission")
+			return
+		}
+
+		// Load the raw tx hex
+		rawTx, loadErr := loadTxHex(txHex, txFile)
+		if loadErr != nil {
+			chalker.Log(chalker.ERROR, fmt.Sprintf("failed to load transaction: %s", loadErr.Error()))
+			return
+		}
+
+		// Submit the transaction
+		chalker.Log(chalker.DEFAULT, fmt.Sprintf("submitting transaction for: %s...", chalk.Cyan.Color(paymailAddress)))
+
+		var txResponse *paymail.P2PTransactionResponse
+		if txResponse, err = paymail.SendP2PTransaction(transactionURL, parts[0], domain, &paymail.P2PTransactionRequest{
+			Hex:       rawTx,
+			Reference: destination.Reference,
+			Metadata: &paymail.P2PTransactionMetadata{
+				Sender:    p2pSender,
+				PubKey:    p2pPubKey,
+				Signature: p2pSignature,
+				Note:      p2pNote,
+			},
+		}); err != nil {
+			chalker.Log(chalker.ERROR, fmt.Sprintf("p2p transaction submission failed: %s", err.Error()))
+			return
+		}
+
+		chalker.Log(chalker.SUCCESS, fmt.Sprintf("transaction accepted: %s", chalk.Cyan.Color(txResponse.TxID)))
+	},
+}
+
+// loadTxHex returns the raw tx hex from either the --tx-hex flag or the file given by --tx-file
+func loadTxHex(hex, file string) (string, error) {
+	if len(hex) > 0 {
+		return strings.TrimSpace(hex), nil
+	}
+
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read --tx-file: %w", err)
+	}
+
+	return strings.TrimSpace(string(raw)), nil
+}
+
+func init() {
+	rootCmd.AddCommand(p2pCmd)
+
+	// Set the satoshi amount to request a payment destination for
+	p2pCmd.Flags().Uint64VarP(&satoshis, "satoshis", "a", 0, "Amount in satoshis to request a p2p payment destination for")
+
+	// Set the raw transaction hex directly
+	p2pCmd.Flags().StringVar(&txHex, "tx-hex", "", "Raw transaction hex to submit (mutually exclusive with --tx-file)")
+
+	// Set the raw transaction hex from a file
+	p2pCmd.Flags().StringVar(&txFile, "tx-file", "", "Path to a file containing the raw transaction hex to submit")
+	p2pCmd.MarkFlagsMutuallyExclusive("tx-hex", "tx-file")
+
+	// Set the sender's handle, used for the basic address resolution fallback
+	p2pCmd.PersistentFlags().String(flagSenderHandle, "", "Sender's paymail handle. Used for the basic address resolution fallback. Receiver paymail used if not specified.")
+	er(viper.BindPFlag(flagSenderHandle, p2pCmd.PersistentFlags().Lookup(flagSenderHandle)))
+
+	// Set the metadata fields for the p2p transaction submission
+	p2pCmd.Flags().StringVar(&p2pSender, "sender", "", "Sender paymail address to include in the transaction metadata")
+	p2pCmd.Flags().StringVar(&p2pPubKey, "pubkey", "", "Sender pubkey to include in the transaction metadata")
+	p2pCmd.Flags().StringVar(&p2pSignature, "signature", "", "Signature of the raw transaction to include in the transaction metadata")
+	p2pCmd.Flags().StringVar(&p2pNote, "note", "", "A human readable note to include in the transaction metadata")
+}
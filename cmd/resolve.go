@@ -12,6 +12,13 @@ import (
 	"github.com/ttacon/chalk"
 )
 
+// senderKey is the sender's private key (WIF or hex) used to sign the address
+// resolution request when sender validation is enforced (see --sender-key)
+var senderKey string
+
+// strictTransport, when set, turns DNSSEC/SSL preflight warnings into hard failures
+var strictTransport bool
+
 // resolveCmd represents the resolve command
 var resolveCmd = &cobra.Command{
 	Use:   "resolve",
@@ -74,6 +81,27 @@ Read more at: `+chalk.Cyan.Color("http://bsvalias.org/04-01-basic-address-resolu
 			}
 		}
 
+		// Preflight: verify DNSSEC + a valid SSL certificate chain, per the bsvalias
+		// requirement that capability URLs be served over HTTPS with DNSSEC-signed records
+		dnsResult := paymail.CheckDNSSEC(domain)
+		if dnsResult.Err != nil {
+			chalker.Log(chalker.WARN, fmt.Sprintf("could not check DNSSEC status for %s: %s", domain, dnsResult.Err.Error()))
+		} else if !dnsResult.Enabled {
+			chalker.Log(chalker.WARN, fmt.Sprintf("%s does not appear to have DNSSEC enabled", domain))
+			if strictTransport {
+				chalker.Log(chalker.ERROR, fmt.Sprintf("--%s set, aborting due to missing DNSSEC", "strict-transport"))
+				return
+			}
+		}
+
+		if ok, sslErr := paymail.CheckSSL(domain, 443); !ok {
+			chalker.Log(chalker.WARN, fmt.Sprintf("%s failed SSL validation: %s", domain, sslErr.Error()))
+			if strictTransport {
+				chalker.Log(chalker.ERROR, fmt.Sprintf("--%s set, aborting due to failed SSL validation", "strict-transport"))
+				return
+			}
+		}
+
 		// Get the capabilities
 		capabilities, err := getCapabilities(domain)
 		if err != nil {
@@ -99,6 +127,15 @@ Read more at: `+chalk.Cyan.Color("http://bsvalias.org/04-01-basic-address-resolu
 			return
 		}
 
+		// Setup the request body - built once and reused for both signing and submission
+		senderRequest := &paymail.AddressResolutionRequest{
+			Amount:       amount,
+			Dt:           time.Now().UTC().Format(time.RFC3339), // UTC is assumed
+			Purpose:      purpose,
+			SenderHandle: senderHandle,
+			SenderName:   viper.GetString(flagSenderName),
+		}
+
 		// Does this provider require sender validation?
 		// https://bsvalias.org/04-02-sender-validation.html
 		if capabilities.GetValueBool(paymail.BRFCSenderValidation, "") {
@@ -106,11 +143,17 @@ Read more at: `+chalk.Cyan.Color("http://bsvalias.org/04-01-basic-address-resolu
 
 			// Required if flag is enforced
 			if len(signature) == 0 {
-				chalker.Log(chalker.ERROR, fmt.Sprintf("missing required flag: %s - see the help section: -h", "--signature"))
+				if len(senderKey) == 0 {
+					chalker.Log(chalker.ERROR, fmt.Sprintf("missing required flag: %s or %s - see the help section: -h", "--signature", "--sender-key"))
+					return
+				}
 
-				// todo: generate a real signature if possible
-				chalker.Log(chalker.WARN, fmt.Sprintf("attempting to fake a signature for: %s...", senderHandle))
-				signature, _ = RandomHex(64)
+				chalker.Log(chalker.DEFAULT, fmt.Sprintf("signing request for: %s...", senderHandle))
+
+				if signature, err = paymail.SignAddressResolutionRequest(senderRequest, senderKey); err != nil {
+					chalker.Log(chalker.ERROR, fmt.Sprintf("failed to sign request: %s", err.Error()))
+					return
+				}
 			}
 
 			// Only if it's not the same (set from above ^^)
@@ -161,15 +204,8 @@ Read more at: `+chalk.Cyan.Color("http://bsvalias.org/04-01-basic-address-resolu
 			return
 		}
 
-		// Setup the request body
-		senderRequest := &paymail.AddressResolutionRequest{
-			Amount:       amount,
-			Dt:           time.Now().UTC().Format(time.RFC3339), // UTC is assumed
-			Purpose:      purpose,
-			SenderHandle: senderHandle,
-			SenderName:   viper.GetString(flagSenderName),
-			Signature:    signature,
-		}
+		// Attach the signature (set above if sender validation was enforced)
+		senderRequest.Signature = signature
 
 		// Resolve the address from a given paymail
 		chalker.Log(chalker.DEFAULT, fmt.Sprintf("resolving address: %s...", chalk.Cyan.Color(parts[0]+"@"+domain)))
@@ -233,9 +269,15 @@ func init() {
 	// Set the signature of the entire request
 	resolveCmd.Flags().StringVarP(&signature, "signature", "s", "", "The signature of the entire request")
 
+	// Set the sender's private key (WIF or hex), used to sign the request when sender validation is enforced
+	resolveCmd.Flags().StringVar(&senderKey, "sender-key", "", "Sender's private key (WIF or hex), used to sign the request when sender validation is enforced")
+
 	// Skip getting the PubKey
 	resolveCmd.Flags().BoolVar(&skipPki, "skip-pki", false, "Skip firing pki request and getting the pubkey")
 
 	// Skip getting public profile
 	resolveCmd.Flags().BoolVar(&skipPublicProfile, "skip-public-profile", false, "Skip firing public profile request and getting the avatar")
+
+	// Fail hard instead of warning when DNSSEC or SSL validation fails
+	resolveCmd.Flags().BoolVar(&strictTransport, "strict-transport", false, "Fail instead of warning when DNSSEC or SSL validation fails")
 }
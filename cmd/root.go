@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// configDefault is the default binary name shown in command examples
+const configDefault = "paymail-inspector"
+
+// rootCmd represents the base command when called without any subcommands
+var rootCmd = &cobra.Command{
+	Use:   configDefault,
+	Short: "Inspect, validate and test paymail addresses and domains",
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// er exits the program if err is non-nil, used for errors that can only come
+// from programmer mistakes (eg: binding an unregistered flag)
+func er(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mrz1836/paymail-inspector/chalker"
+	"github.com/mrz1836/paymail-inspector/paymail"
+	"github.com/spf13/cobra"
+	"github.com/ttacon/chalk"
+)
+
+// Flags for the serve command
+var (
+	serveDomain      string
+	servePort        int
+	serveServiceName string
+	serveEnableP2P   bool
+	serveCapability  []string
+	serveIdentity    []string
+	serveAddress     []string
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Runs a local paymail server",
+	Long: chalk.Green.Color(`
+   _________________ ____   ____
+  /  ___/\_  __ \__  \ \  \ /  /
+  \___ \  |  | \// __ \_\   Y  /
+ /____  > |__|  (____  / \___/
+      \/              \/      `) + `
+` + chalk.Yellow.Color(`
+Starts a local HTTP server exposing the bsvalias capabilities document, PKI and
+address resolution endpoints (and optionally the P2P endpoints), backed by an
+in-memory paymail.ServiceProvider for local testing.
+
+To back the server with your own data instead, implement paymail.ServiceProvider
+and use the paymail package as a library rather than this command.
+
+Read more at: `+chalk.Cyan.Color("http://bsvalias.org/01-02-bsvalias-http-api.html")),
+	Aliases: []string{"server", "run"},
+	Example: configDefault + ` serve --domain example.com --identity alice@example.com=02ab...`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if len(serveDomain) == 0 {
+			chalker.Log(chalker.ERROR, "missing required flag: --domain")
+			return
+		}
+
+		provider := paymail.NewMemoryServiceProvider()
+
+		for _, identity := range serveIdentity {
+			alias, pubKey, err := splitKeyValue(identity)
+			if err != nil {
+				chalker.Log(chalker.ERROR, fmt.Sprintf("invalid --identity: %s", err.Error()))
+				return
+			}
+			provider.Identities[alias] = pubKey
+		}
+
+		for _, address := range serveAddress {
+			alias, script, err := splitKeyValue(address)
+			if err != nil {
+				chalker.Log(chalker.ERROR, fmt.Sprintf("invalid --address: %s", err.Error()))
+				return
+			}
+			provider.OutputScripts[alias] = script
+		}
+
+		capabilities := make(map[string]string, len(serveCapability))
+		for _, capability := range serveCapability {
+			id, url, err := splitKeyValue(capability)
+			if err != nil {
+				chalker.Log(chalker.ERROR, fmt.Sprintf("invalid --capability: %s", err.Error()))
+				return
+			}
+			capabilities[id] = url
+		}
+
+		config := &paymail.ServerConfig{
+			Domain:       serveDomain,
+			Port:         servePort,
+			ServiceName:  serveServiceName,
+			EnableP2P:    serveEnableP2P,
+			Capabilities: capabilities,
+		}
+
+		server := paymail.NewServer(provider, config)
+
+		chalker.Log(chalker.SUCCESS, fmt.Sprintf("serving paymail for %s on port %d...", chalk.Cyan.Color(serveDomain), servePort))
+		if err := server.ListenAndServe(); err != nil {
+			chalker.Log(chalker.ERROR, fmt.Sprintf("server stopped: %s", err.Error()))
+		}
+	},
+}
+
+// splitKeyValue splits a "key=value" flag value into its parts
+func splitKeyValue(s string) (key, value string, err error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", fmt.Errorf("expected key=value, got: %s", s)
+	}
+	return parts[0], parts[1], nil
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	// Set the domain this server is serving paymail for
+	serveCmd.Flags().StringVar(&serveDomain, "domain", "", "Domain this server is serving paymail for (required)")
+
+	// Set the port to listen on
+	serveCmd.Flags().IntVar(&servePort, "port", 8080, "Port to listen on")
+
+	// Set the service name advertised in the capabilities document
+	serveCmd.Flags().StringVar(&serveServiceName, "service-name", "paymail-inspector", "Service name advertised in the capabilities document")
+
+	// Enable the P2P capabilities/endpoints
+	serveCmd.Flags().BoolVar(&serveEnableP2P, "enable-p2p", false, "Enable the P2P Payment Destination and P2P Transaction endpoints")
+
+	// Set extra/override capabilities, eg: --capability pki=https://example.com/id/{alias}@{domain}
+	serveCmd.Flags().StringArrayVar(&serveCapability, "capability", nil, "Extra/override capability, format: brfc_id=url (repeatable)")
+
+	// Seed the in-memory provider with identities, eg: --identity alice@example.com=02ab...
+	serveCmd.Flags().StringArrayVar(&serveIdentity, "identity", nil, "Seed the in-memory provider with an identity, format: alias@domain=pubkey (repeatable)")
+
+	// Seed the in-memory provider with output scripts, eg: --address alice@example.com=76a914...88ac
+	serveCmd.Flags().StringArrayVar(&serveAddress, "address", nil, "Seed the in-memory provider with an output script, format: alias@domain=script (repeatable)")
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mrz1836/paymail-inspector/chalker"
+	"github.com/mrz1836/paymail-inspector/paymail"
+)
+
+// validatePaymailAndDomain does basic sanity checking on a paymail address and
+// its extracted domain, logging an error (and returning false) if invalid
+func validatePaymailAndDomain(paymailAddress, domain string) bool {
+	if len(domain) == 0 || !strings.Contains(domain, ".") {
+		chalker.Log(chalker.ERROR, fmt.Sprintf("invalid domain: %s", domain))
+		return false
+	} else if !strings.Contains(paymailAddress, "@") {
+		chalker.Log(chalker.ERROR, fmt.Sprintf("invalid paymail address: %s", paymailAddress))
+		return false
+	}
+	return true
+}
+
+// getCapabilities is a thin wrapper around paymail.GetCapabilities
+func getCapabilities(domain string) (*paymail.Capabilities, error) {
+	return paymail.GetCapabilities(domain)
+}
+
+// getPki is a thin wrapper around paymail.GetPKI
+func getPki(pkiURL, alias, domain string) (*paymail.PKIResponse, error) {
+	if skipPki {
+		return nil, nil
+	}
+	return paymail.GetPKI(pkiURL, alias, domain)
+}
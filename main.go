@@ -0,0 +1,7 @@
+package main
+
+import "github.com/mrz1836/paymail-inspector/cmd"
+
+func main() {
+	cmd.Execute()
+}
@@ -0,0 +1,13 @@
+package paymail
+
+// BRFC IDs for the core bsvalias capabilities
+//
+// Read more at: http://bsvalias.org/01-02-bsvalias-http-api.html
+const (
+	BRFCPki                    = "0c4339ef99c2" // PKI
+	BRFCPkiAlternate           = "pki"          // Alternate/legacy key some providers publish PKI under
+	BRFCPaymentDestination     = "f12f968c92d6" // Payment Destination (supersedes Basic Address Resolution)
+	BRFCBasicAddressResolution = "759684b1a19a" // Basic Address Resolution
+	BRFCSenderValidation       = "6745385c3fc0" // Sender Validation
+	BRFCPublicProfile          = "f636e12ee6b2" // Public Profile
+)
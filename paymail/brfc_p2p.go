@@ -0,0 +1,11 @@
+package paymail
+
+// BRFC IDs for the P2P Payment Destination and P2P Transaction capabilities
+//
+// Read more at:
+// https://docs.moneybutton.com/docs/paymail-07-p2p-payment-destination.html
+// https://docs.moneybutton.com/docs/paymail-06-p2p-transactions.html
+const (
+	BRFCP2PPaymentDestination = "2a1c8bb47b60" // P2P Payment Destination
+	BRFCP2PTransaction        = "5f1323cddf31" // P2P Transaction (send raw tx + metadata)
+)
@@ -0,0 +1,97 @@
+package paymail
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// srvService/srvProto are the SRV record service/proto bsvalias uses to locate
+// the capabilities document host/port for a domain
+const (
+	srvService = "bsvalias"
+	srvProto   = "tcp"
+)
+
+// Capabilities is the parsed /.well-known/bsvalias document for a domain
+type Capabilities struct {
+	BsvAlias     string                 `json:"bsvalias"`
+	Capabilities map[string]interface{} `json:"capabilities"`
+}
+
+// GetValueString returns the capability value for key (or alternateKey, if key
+// is not present) as a string, or an empty string if neither is found or the
+// value is not a string.
+func (c *Capabilities) GetValueString(key, alternateKey string) string {
+	value, ok := c.Capabilities[key]
+	if !ok && len(alternateKey) > 0 {
+		value, ok = c.Capabilities[alternateKey]
+	}
+	if !ok {
+		return ""
+	}
+
+	str, _ := value.(string)
+	return str
+}
+
+// GetValueBool returns the capability value for key (or alternateKey, if key is
+// not present) as a bool, or false if neither is found or the value is not a bool.
+func (c *Capabilities) GetValueBool(key, alternateKey string) bool {
+	value, ok := c.Capabilities[key]
+	if !ok && len(alternateKey) > 0 {
+		value, ok = c.Capabilities[alternateKey]
+	}
+	if !ok {
+		return false
+	}
+
+	b, _ := value.(bool)
+	return b
+}
+
+// GetCapabilities performs SRV-based service discovery for domain per the
+// bsvalias spec, then fetches and parses the resulting /.well-known/bsvalias
+// capabilities document.
+//
+// Read more at: http://bsvalias.org/01-01-host-discovery.html
+func GetCapabilities(domain string) (*Capabilities, error) {
+	host, port := domain, 443
+
+	_, addresses, err := net.LookupSRV(srvService, srvProto, domain)
+	if err == nil && len(addresses) > 0 {
+		host = trimTrailingDot(addresses[0].Target)
+		port = int(addresses[0].Port)
+	}
+
+	url := fmt.Sprintf("https://%s:%d/.well-known/bsvalias", host, port)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get capabilities: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("capabilities request failed with status: %d", resp.StatusCode)
+	}
+
+	capabilities := new(Capabilities)
+	if err = json.NewDecoder(resp.Body).Decode(capabilities); err != nil {
+		return nil, fmt.Errorf("failed to decode capabilities: %w", err)
+	}
+
+	return capabilities, nil
+}
+
+// trimTrailingDot removes the trailing "." from a fully-qualified SRV target
+func trimTrailingDot(host string) string {
+	if len(host) > 0 && host[len(host)-1] == '.' {
+		return host[:len(host)-1]
+	}
+	return host
+}
@@ -0,0 +1,97 @@
+package paymail
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Contact is a locally persisted paymail identity, built from the PKI + public
+// profile capabilities the first time a contact is added.
+type Contact struct {
+	Paymail string `json:"paymail"`
+	PubKey  string `json:"pubkey"`
+	Name    string `json:"name,omitempty"`
+	Avatar  string `json:"avatar,omitempty"`
+	AddedAt string `json:"added_at"`
+}
+
+// ContactStore is a simple JSON file backed store of Contact records, keyed by
+// paymail address, used by the contact command group.
+type ContactStore struct {
+	path     string
+	contacts map[string]*Contact
+}
+
+// LoadContactStore reads the contact store from path, returning an empty store
+// if the file does not yet exist.
+func LoadContactStore(path string) (*ContactStore, error) {
+	store := &ContactStore{path: path, contacts: make(map[string]*Contact)}
+
+	raw, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read contact store: %w", err)
+	}
+
+	if len(raw) == 0 {
+		return store, nil
+	}
+
+	if err = json.Unmarshal(raw, &store.contacts); err != nil {
+		return nil, fmt.Errorf("failed to parse contact store: %w", err)
+	}
+
+	return store, nil
+}
+
+// Save writes the contact store back to disk as JSON, creating the parent
+// directory if needed.
+func (s *ContactStore) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create contact store directory: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(s.contacts, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal contact store: %w", err)
+	}
+
+	if err = ioutil.WriteFile(s.path, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write contact store: %w", err)
+	}
+
+	return nil
+}
+
+// Add inserts or overwrites a contact, keyed by its paymail address
+func (s *ContactStore) Add(contact *Contact) {
+	s.contacts[contact.Paymail] = contact
+}
+
+// Get returns the contact for a given paymail address, if known
+func (s *ContactStore) Get(paymail string) (*Contact, bool) {
+	contact, ok := s.contacts[paymail]
+	return contact, ok
+}
+
+// Remove deletes a contact, returning false if it was not found
+func (s *ContactStore) Remove(paymail string) bool {
+	if _, ok := s.contacts[paymail]; !ok {
+		return false
+	}
+	delete(s.contacts, paymail)
+	return true
+}
+
+// List returns all known contacts
+func (s *ContactStore) List() []*Contact {
+	contacts := make([]*Contact, 0, len(s.contacts))
+	for _, contact := range s.contacts {
+		contacts = append(contacts, contact)
+	}
+	return contacts
+}
@@ -0,0 +1,75 @@
+package paymail
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestContactStore_LoadMissingFileReturnsEmptyStore(t *testing.T) {
+	store, err := LoadContactStore(filepath.Join(t.TempDir(), "contacts.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if len(store.List()) != 0 {
+		t.Error("expected an empty store for a missing file")
+	}
+}
+
+func TestContactStore_AddGetRemove(t *testing.T) {
+	store, err := LoadContactStore(filepath.Join(t.TempDir(), "contacts.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	contact := &Contact{Paymail: "alice@example.com", PubKey: "02abcdef"}
+	store.Add(contact)
+
+	got, ok := store.Get("alice@example.com")
+	if !ok || got.PubKey != "02abcdef" {
+		t.Fatalf("expected to find the added contact, got: %+v, ok=%v", got, ok)
+	}
+
+	if len(store.List()) != 1 {
+		t.Errorf("expected exactly one contact, got: %d", len(store.List()))
+	}
+
+	if !store.Remove("alice@example.com") {
+		t.Error("expected Remove to report the contact was found")
+	}
+
+	if _, ok = store.Get("alice@example.com"); ok {
+		t.Error("expected the contact to be gone after Remove")
+	}
+
+	if store.Remove("alice@example.com") {
+		t.Error("expected Remove to report false for an already-removed contact")
+	}
+}
+
+func TestContactStore_SaveAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contacts.json")
+
+	store, err := LoadContactStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	store.Add(&Contact{Paymail: "alice@example.com", PubKey: "02abcdef", Name: "Alice"})
+	if err = store.Save(); err != nil {
+		t.Fatalf("unexpected error saving: %s", err.Error())
+	}
+
+	reloaded, err := LoadContactStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error reloading: %s", err.Error())
+	}
+
+	contact, ok := reloaded.Get("alice@example.com")
+	if !ok {
+		t.Fatal("expected the saved contact to round-trip")
+	}
+	if contact.PubKey != "02abcdef" || contact.Name != "Alice" {
+		t.Errorf("unexpected round-tripped contact: %+v", contact)
+	}
+}
@@ -0,0 +1,78 @@
+package paymail
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// dohEndpoint is the DNS-over-HTTPS resolver used to check DNSSEC status.
+// 1.1.1.1 (Cloudflare) is a trusted, DNSSEC-validating resolver. Declared as a
+// var (rather than a const) so tests can point it at a local test server.
+var dohEndpoint = "https://cloudflare-dns.com/dns-query"
+
+// dohResponse is the subset of the DNS-over-HTTPS JSON response we care about
+// https://developers.cloudflare.com/1.1.1.1/encryption/dns-over-https/make-api-requests/dns-json/
+type dohResponse struct {
+	Status int  `json:"Status"`
+	AD     bool `json:"AD"` // Authenticated Data - true if DNSSEC validation succeeded
+}
+
+// DNSCheckResult is the result of a CheckDNSSEC lookup
+type DNSCheckResult struct {
+	Domain  string // Domain that was checked
+	Enabled bool   // Whether the resolver reported the AD (Authenticated Data) bit
+	Err     error  // Set if the lookup itself failed; Enabled is not meaningful in that case
+}
+
+// CheckDNSSEC asks a trusted, DNSSEC-validating resolver (1.1.1.1 over DoH) whether
+// the given domain's A record was validated with DNSSEC. A non-nil Err means the
+// lookup itself failed (network error, blocked DoH, etc.) and Enabled should not be
+// treated as a negative result - callers should only warn/fail on Enabled when Err
+// is nil.
+//
+// Read more at: http://bsvalias.org/02-02-http-transport.html
+func CheckDNSSEC(domain string) *DNSCheckResult {
+	result := &DNSCheckResult{Domain: domain}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	req, err := http.NewRequest(http.MethodGet, dohEndpoint, nil)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to build dns-over-https request: %w", err)
+		return result
+	}
+	req.Header.Set("Accept", "application/dns-json")
+
+	q := req.URL.Query()
+	q.Set("name", domain)
+	q.Set("type", "A")
+	q.Set("do", "1")
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Err = fmt.Errorf("dns-over-https lookup failed: %w", err)
+		return result
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var parsed dohResponse
+	if err = json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		result.Err = fmt.Errorf("failed to decode dns-over-https response: %w", err)
+		return result
+	}
+
+	result.Enabled = parsed.Status == 0 && parsed.AD
+
+	return result
+}
+
+// String returns a human-readable summary of the DNSSEC check result
+func (d *DNSCheckResult) String() string {
+	if d.Enabled {
+		return fmt.Sprintf("%s: DNSSEC enabled", d.Domain)
+	}
+	return fmt.Sprintf("%s: DNSSEC NOT enabled", d.Domain)
+}
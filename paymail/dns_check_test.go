@@ -0,0 +1,72 @@
+package paymail
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// withDoHEndpoint points dohEndpoint at a test server for the duration of a test
+func withDoHEndpoint(t *testing.T, handler http.HandlerFunc) {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	original := dohEndpoint
+	dohEndpoint = srv.URL
+	t.Cleanup(func() { dohEndpoint = original })
+}
+
+func TestCheckDNSSEC_ADBitSet(t *testing.T) {
+	withDoHEndpoint(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Status":0,"AD":true}`)
+	})
+
+	result := CheckDNSSEC("example.com")
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %s", result.Err.Error())
+	}
+	if !result.Enabled {
+		t.Error("expected DNSSEC to be reported as enabled when AD is true")
+	}
+}
+
+func TestCheckDNSSEC_ADBitNotSet(t *testing.T) {
+	withDoHEndpoint(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Status":0,"AD":false}`)
+	})
+
+	result := CheckDNSSEC("example.com")
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %s", result.Err.Error())
+	}
+	if result.Enabled {
+		t.Error("expected DNSSEC to be reported as disabled when AD is false")
+	}
+}
+
+func TestCheckDNSSEC_NonZeroStatusNotEnabled(t *testing.T) {
+	withDoHEndpoint(t, func(w http.ResponseWriter, r *http.Request) {
+		// AD true but a non-zero Status (eg NXDOMAIN) must not count as enabled
+		fmt.Fprint(w, `{"Status":3,"AD":true}`)
+	})
+
+	result := CheckDNSSEC("example.com")
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %s", result.Err.Error())
+	}
+	if result.Enabled {
+		t.Error("expected DNSSEC to be reported as disabled for a non-zero Status")
+	}
+}
+
+func TestCheckDNSSEC_LookupFailureSetsErr(t *testing.T) {
+	withDoHEndpoint(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	result := CheckDNSSEC("example.com")
+	if result.Err == nil {
+		t.Error("expected an error when the dns-over-https response can't be decoded")
+	}
+}
@@ -0,0 +1,24 @@
+package paymail
+
+import "strings"
+
+// ExtractParts normalizes a raw paymail address (lower-cased, trimmed, with any
+// "mailto:" or leading/trailing slashes stripped) and splits it into its domain
+// and full handle. If the input does not contain a valid "alias@domain" handle,
+// both return values are empty strings.
+func ExtractParts(rawPaymail string) (domain string, paymailAddress string) {
+	address := strings.ToLower(strings.TrimSpace(rawPaymail))
+	address = strings.TrimPrefix(address, "mailto:")
+	address = strings.Trim(address, "/")
+
+	if len(address) == 0 {
+		return "", ""
+	}
+
+	parts := strings.SplitN(address, "@", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", ""
+	}
+
+	return parts[1], address
+}
@@ -0,0 +1,145 @@
+package paymail
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// P2POutput is a single output returned by a P2P Payment Destination request,
+// describing where the sender should pay satoshis to.
+type P2POutput struct {
+	Address  string `json:"address,omitempty"`
+	Satoshis uint64 `json:"satoshis,omitempty"`
+	Script   string `json:"script,omitempty"`
+}
+
+// P2PPaymentDestinationResponse is returned by GetP2PPaymentDestination
+type P2PPaymentDestinationResponse struct {
+	Outputs   []*P2POutput `json:"outputs"`
+	Reference string       `json:"reference"`
+}
+
+// P2PTransactionMetadata is optional metadata submitted alongside a P2P transaction
+type P2PTransactionMetadata struct {
+	Sender    string `json:"sender,omitempty"`
+	PubKey    string `json:"pubkey,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Note      string `json:"note,omitempty"`
+}
+
+// P2PTransactionRequest is the body sent to SendP2PTransaction
+type P2PTransactionRequest struct {
+	Hex       string                  `json:"hex"`
+	Reference string                  `json:"reference"`
+	Metadata  *P2PTransactionMetadata `json:"metadata,omitempty"`
+}
+
+// P2PTransactionResponse is returned by SendP2PTransaction
+type P2PTransactionResponse struct {
+	TxID string `json:"txid"`
+	Note string `json:"note,omitempty"`
+}
+
+// GetP2PPaymentDestination fires a P2P Payment Destination request (BRFC 2a1c8bb47b60)
+// against the given capability url, asking the receiver for one or more outputs to
+// pay satoshis to.
+//
+// Read more at: https://docs.moneybutton.com/docs/paymail-07-p2p-payment-destination.html
+func GetP2PPaymentDestination(destinationURL, alias, domain string, satoshis uint64) (*P2PPaymentDestinationResponse, error) {
+	if len(destinationURL) == 0 {
+		return nil, fmt.Errorf("missing p2p payment destination url")
+	}
+
+	destinationURL = replaceAliasDomain(destinationURL, alias, domain)
+
+	body, err := json.Marshal(map[string]uint64{"satoshis": satoshis})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal p2p payment destination request: %w", err)
+	}
+
+	resp := new(P2PPaymentDestinationResponse)
+	if err = postJSON(destinationURL, body, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// SendP2PTransaction submits a raw transaction + reference + metadata to the receiver
+// using the P2P Transaction capability (BRFC 5f1323cddf31), completing a P2P payment
+// that was started with GetP2PPaymentDestination.
+//
+// Read more at: https://docs.moneybutton.com/docs/paymail-06-p2p-transactions.html
+func SendP2PTransaction(transactionURL, alias, domain string, request *P2PTransactionRequest) (*P2PTransactionResponse, error) {
+	if len(transactionURL) == 0 {
+		return nil, fmt.Errorf("missing p2p transaction url")
+	} else if request == nil || len(request.Hex) == 0 {
+		return nil, fmt.Errorf("missing transaction hex")
+	} else if len(request.Reference) == 0 {
+		return nil, fmt.Errorf("missing reference")
+	}
+
+	transactionURL = replaceAliasDomain(transactionURL, alias, domain)
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal p2p transaction request: %w", err)
+	}
+
+	resp := new(P2PTransactionResponse)
+	if err = postJSON(transactionURL, body, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// replaceAliasDomain fills in the {alias} and {domain} template placeholders found
+// in a paymail capability url, per the bsvalias service discovery spec.
+func replaceAliasDomain(url, alias, domain string) string {
+	url = strings.Replace(url, "{alias}", alias, -1)
+	url = strings.Replace(url, "{domain}", domain, -1)
+	return url
+}
+
+// postJSON is a small helper that posts a JSON body to a url and decodes the
+// JSON response into v.
+func postJSON(url string, body []byte, v interface{}) error {
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("request failed with status: %d - %s", resp.StatusCode, string(respBody))
+	}
+
+	if len(respBody) == 0 {
+		return nil
+	}
+
+	if err = json.Unmarshal(respBody, v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
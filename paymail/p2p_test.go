@@ -0,0 +1,87 @@
+package paymail
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetP2PPaymentDestination_MissingURL(t *testing.T) {
+	if _, err := GetP2PPaymentDestination("", "alice", "example.com", 1000); err == nil {
+		t.Error("expected an error for a missing destination url")
+	}
+}
+
+func TestGetP2PPaymentDestination_Success(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Satoshis uint64 `json:"satoshis"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Satoshis != 1000 {
+			t.Errorf("expected satoshis 1000, got: %d", body.Satoshis)
+		}
+
+		_ = json.NewEncoder(w).Encode(&P2PPaymentDestinationResponse{
+			Outputs:   []*P2POutput{{Script: "76a914...88ac"}},
+			Reference: "abc123",
+		})
+	}))
+	defer srv.Close()
+
+	resp, err := GetP2PPaymentDestination(srv.URL+"/{alias}@{domain}", "alice", "example.com", 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if resp.Reference != "abc123" {
+		t.Errorf("expected reference abc123, got: %s", resp.Reference)
+	}
+	if len(resp.Outputs) != 1 || resp.Outputs[0].Script != "76a914...88ac" {
+		t.Errorf("unexpected outputs: %+v", resp.Outputs)
+	}
+}
+
+func TestSendP2PTransaction_MissingTransactionURL(t *testing.T) {
+	if _, err := SendP2PTransaction("", "alice", "example.com", &P2PTransactionRequest{Hex: "deadbeef", Reference: "ref"}); err == nil {
+		t.Error("expected an error for a missing transaction url")
+	}
+}
+
+func TestSendP2PTransaction_MissingHex(t *testing.T) {
+	if _, err := SendP2PTransaction("http://example.com", "alice", "example.com", &P2PTransactionRequest{Reference: "ref"}); err == nil {
+		t.Error("expected an error for a missing transaction hex")
+	}
+}
+
+func TestSendP2PTransaction_MissingReference(t *testing.T) {
+	if _, err := SendP2PTransaction("http://example.com", "alice", "example.com", &P2PTransactionRequest{Hex: "deadbeef"}); err == nil {
+		t.Error("expected an error for a missing reference")
+	}
+}
+
+func TestSendP2PTransaction_Success(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req P2PTransactionRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Hex != "deadbeef" || req.Reference != "abc123" {
+			t.Errorf("unexpected request body: %+v", req)
+		}
+
+		_ = json.NewEncoder(w).Encode(&P2PTransactionResponse{TxID: "txid123"})
+	}))
+	defer srv.Close()
+
+	resp, err := SendP2PTransaction(srv.URL+"/{alias}@{domain}", "alice", "example.com", &P2PTransactionRequest{
+		Hex:       "deadbeef",
+		Reference: "abc123",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if resp.TxID != "txid123" {
+		t.Errorf("expected txid123, got: %s", resp.TxID)
+	}
+}
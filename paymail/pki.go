@@ -0,0 +1,46 @@
+package paymail
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// PKIResponse is the response from a paymail provider's PKI endpoint
+type PKIResponse struct {
+	BsvAlias string `json:"bsvalias"`
+	Handle   string `json:"handle"`
+	PubKey   string `json:"pubkey"`
+}
+
+// GetPKI fires a PKI request against pkiURL for alias@domain, returning the
+// provider's published pubkey for that paymail address.
+//
+// Read more at: http://bsvalias.org/03-public-key-infrastructure.html
+func GetPKI(pkiURL, alias, domain string) (*PKIResponse, error) {
+	if len(pkiURL) == 0 {
+		return nil, fmt.Errorf("missing pki url")
+	}
+
+	url := replaceAliasDomain(pkiURL, alias, domain)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("pki request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("pki request failed with status: %d", resp.StatusCode)
+	}
+
+	pki := new(PKIResponse)
+	if err = json.NewDecoder(resp.Body).Decode(pki); err != nil {
+		return nil, fmt.Errorf("failed to decode pki response: %w", err)
+	}
+
+	return pki, nil
+}
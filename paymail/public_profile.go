@@ -0,0 +1,45 @@
+package paymail
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// PublicProfileResponse is the response from a paymail provider's Public Profile endpoint
+type PublicProfileResponse struct {
+	Name   string `json:"name,omitempty"`
+	Avatar string `json:"avatar,omitempty"`
+}
+
+// GetPublicProfile fires a Public Profile request against profileURL for
+// alias@domain, returning the receiver's public name/avatar (if set).
+//
+// Read more at: https://docs.moneybutton.com/docs/paymail-08-public-profile.html
+func GetPublicProfile(profileURL, alias, domain string) (*PublicProfileResponse, error) {
+	if len(profileURL) == 0 {
+		return nil, fmt.Errorf("missing public profile url")
+	}
+
+	url := replaceAliasDomain(profileURL, alias, domain)
+
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("public profile request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("public profile request failed with status: %d", resp.StatusCode)
+	}
+
+	profile := new(PublicProfileResponse)
+	if err = json.NewDecoder(resp.Body).Decode(profile); err != nil {
+		return nil, fmt.Errorf("failed to decode public profile response: %w", err)
+	}
+
+	return profile, nil
+}
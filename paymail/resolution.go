@@ -0,0 +1,50 @@
+package paymail
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AddressResolutionRequest is the body sent to a Basic Address Resolution /
+// Payment Destination endpoint
+type AddressResolutionRequest struct {
+	Amount       uint64 `json:"amount,omitempty"`
+	Dt           string `json:"dt"`
+	Purpose      string `json:"purpose,omitempty"`
+	SenderHandle string `json:"senderHandle"`
+	SenderName   string `json:"senderName,omitempty"`
+	Signature    string `json:"signature,omitempty"`
+}
+
+// AddressResolutionResponse is returned by AddressResolution
+type AddressResolutionResponse struct {
+	Output  string `json:"output"`
+	Address string `json:"address,omitempty"`
+}
+
+// AddressResolution fires a Basic Address Resolution / Payment Destination
+// request against resolveURL for alias@domain, returning the output script
+// (and address, if the provider returns one).
+//
+// Read more at: http://bsvalias.org/04-01-basic-address-resolution.html
+func AddressResolution(resolveURL, alias, domain string, request *AddressResolutionRequest) (*AddressResolutionResponse, error) {
+	if len(resolveURL) == 0 {
+		return nil, fmt.Errorf("missing address resolution url")
+	} else if request == nil {
+		return nil, fmt.Errorf("missing request body")
+	}
+
+	url := replaceAliasDomain(resolveURL, alias, domain)
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal address resolution request: %w", err)
+	}
+
+	resp := new(AddressResolutionResponse)
+	if err = postJSON(url, body, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,200 @@
+package paymail
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ServerConfig configures the paymail HTTP server built by NewServer
+type ServerConfig struct {
+	Domain       string            // Domain this server is serving paymail for (eg: example.com)
+	Port         int               // Port to listen on
+	ServiceName  string            // Service name advertised in the capabilities document
+	EnableP2P    bool              // Whether to advertise/expose the P2P capabilities
+	Capabilities map[string]string // Extra/override capability id -> url template entries
+}
+
+// NewServer builds an *http.Server exposing the bsvalias capabilities document plus
+// the PKI, address resolution and (optionally) P2P endpoints, all backed by the
+// given ServiceProvider.
+//
+// Read more at: http://bsvalias.org/01-02-bsvalias-http-api.html
+func NewServer(provider ServiceProvider, config *ServerConfig) *http.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/.well-known/bsvalias", capabilitiesHandler(config))
+	mux.HandleFunc("/id/", pkiHandler(provider))
+	mux.HandleFunc("/address/", addressResolutionHandler(provider))
+
+	if config.EnableP2P {
+		mux.HandleFunc("/api/paymail/", p2pHandler(provider))
+	}
+
+	return &http.Server{
+		Addr:    fmt.Sprintf(":%d", config.Port),
+		Handler: mux,
+	}
+}
+
+// capabilitiesHandler serves the /.well-known/bsvalias capabilities document
+func capabilitiesHandler(config *ServerConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		base := "https://" + config.Domain
+
+		capabilities := map[string]interface{}{
+			BRFCPki:                    base + "/id/{alias}@{domain}",
+			BRFCBasicAddressResolution: base + "/address/{alias}@{domain}",
+		}
+
+		if config.EnableP2P {
+			capabilities[BRFCP2PPaymentDestination] = base + "/api/paymail/destination/{alias}@{domain}"
+			capabilities[BRFCP2PTransaction] = base + "/api/paymail/transaction/{alias}@{domain}"
+		}
+
+		for id, url := range config.Capabilities {
+			capabilities[id] = url
+		}
+
+		doc := map[string]interface{}{
+			"bsvalias":     "1.0",
+			"capabilities": capabilities,
+		}
+		if len(config.ServiceName) > 0 {
+			doc["serviceName"] = config.ServiceName
+		}
+
+		writeJSON(w, http.StatusOK, doc)
+	}
+}
+
+// pkiHandler serves GET /id/{alias}@{domain}
+func pkiHandler(provider ServiceProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		alias, domain, err := parseAliasDomain(strings.TrimPrefix(r.URL.Path, "/id/"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		pki, err := provider.GetPKI(alias, domain)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, pki)
+	}
+}
+
+// addressResolutionHandler serves POST /address/{alias}@{domain}
+func addressResolutionHandler(provider ServiceProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed"))
+			return
+		}
+
+		alias, domain, err := parseAliasDomain(strings.TrimPrefix(r.URL.Path, "/address/"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		req := new(AddressResolutionRequest)
+		if err = json.NewDecoder(r.Body).Decode(req); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+			return
+		}
+
+		resp, err := provider.CreateAddressResolutionResponse(alias, domain, req)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+// p2pHandler serves POST /api/paymail/destination/{alias}@{domain} and
+// POST /api/paymail/transaction/{alias}@{domain}
+func p2pHandler(provider ServiceProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed"))
+			return
+		}
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/paymail/destination/"):
+			alias, domain, err := parseAliasDomain(strings.TrimPrefix(r.URL.Path, "/api/paymail/destination/"))
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+
+			var body struct {
+				Satoshis uint64 `json:"satoshis"`
+			}
+			if err = json.NewDecoder(r.Body).Decode(&body); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+				return
+			}
+
+			resp, err := provider.CreateP2PDestinationResponse(alias, domain, body.Satoshis)
+			if err != nil {
+				writeError(w, http.StatusNotFound, err)
+				return
+			}
+
+			writeJSON(w, http.StatusOK, resp)
+
+		case strings.HasPrefix(r.URL.Path, "/api/paymail/transaction/"):
+			alias, domain, err := parseAliasDomain(strings.TrimPrefix(r.URL.Path, "/api/paymail/transaction/"))
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+
+			req := new(P2PTransactionRequest)
+			if err = json.NewDecoder(r.Body).Decode(req); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+				return
+			}
+
+			resp, err := provider.RecordTransaction(alias, domain, req)
+			if err != nil {
+				writeError(w, http.StatusNotFound, err)
+				return
+			}
+
+			writeJSON(w, http.StatusOK, resp)
+
+		default:
+			writeError(w, http.StatusNotFound, fmt.Errorf("not found"))
+		}
+	}
+}
+
+// parseAliasDomain splits an "{alias}@{domain}" path segment into its parts
+func parseAliasDomain(path string) (alias, domain string, err error) {
+	parts := strings.SplitN(path, "@", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", fmt.Errorf("invalid alias@domain path: %s", path)
+	}
+	return parts[0], parts[1], nil
+}
+
+// writeJSON writes a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error response with the given status code
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"message": err.Error()})
+}
@@ -0,0 +1,186 @@
+package paymail
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func testServer(provider ServiceProvider, config *ServerConfig) *httptest.Server {
+	return httptest.NewServer(NewServer(provider, config).Handler)
+}
+
+func TestCapabilitiesHandler_IncludesServiceName(t *testing.T) {
+	config := &ServerConfig{Domain: "example.com", ServiceName: "my-paymail-service", EnableP2P: true}
+	srv := testServer(NewMemoryServiceProvider(), config)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/.well-known/bsvalias")
+	if err != nil {
+		t.Fatalf("request failed: %s", err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var doc map[string]interface{}
+	if err = json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatalf("failed to decode response: %s", err.Error())
+	}
+
+	if doc["serviceName"] != "my-paymail-service" {
+		t.Errorf("expected serviceName to be advertised, got: %v", doc["serviceName"])
+	}
+
+	capabilities, _ := doc["capabilities"].(map[string]interface{})
+	if _, ok := capabilities[BRFCP2PPaymentDestination]; !ok {
+		t.Error("expected p2p payment destination capability to be advertised when EnableP2P is set")
+	}
+}
+
+func TestCapabilitiesHandler_OmitsServiceNameWhenUnset(t *testing.T) {
+	srv := testServer(NewMemoryServiceProvider(), &ServerConfig{Domain: "example.com"})
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/.well-known/bsvalias")
+	if err != nil {
+		t.Fatalf("request failed: %s", err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var doc map[string]interface{}
+	if err = json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatalf("failed to decode response: %s", err.Error())
+	}
+
+	if _, ok := doc["serviceName"]; ok {
+		t.Error("expected serviceName to be omitted when not configured")
+	}
+}
+
+func TestPKIHandler(t *testing.T) {
+	provider := NewMemoryServiceProvider()
+	provider.Identities["alice@example.com"] = "02abcdef"
+
+	srv := testServer(provider, &ServerConfig{Domain: "example.com"})
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/id/alice@example.com")
+	if err != nil {
+		t.Fatalf("request failed: %s", err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got: %d", resp.StatusCode)
+	}
+
+	var pki PKIResponse
+	if err = json.NewDecoder(resp.Body).Decode(&pki); err != nil {
+		t.Fatalf("failed to decode response: %s", err.Error())
+	}
+
+	if pki.PubKey != "02abcdef" {
+		t.Errorf("expected pubkey 02abcdef, got: %s", pki.PubKey)
+	}
+}
+
+func TestPKIHandler_UnknownIdentity(t *testing.T) {
+	srv := testServer(NewMemoryServiceProvider(), &ServerConfig{Domain: "example.com"})
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/id/unknown@example.com")
+	if err != nil {
+		t.Fatalf("request failed: %s", err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status 404, got: %d", resp.StatusCode)
+	}
+}
+
+func TestAddressResolutionHandler(t *testing.T) {
+	provider := NewMemoryServiceProvider()
+	provider.OutputScripts["alice@example.com"] = "76a914...88ac"
+
+	srv := testServer(provider, &ServerConfig{Domain: "example.com"})
+	defer srv.Close()
+
+	body, _ := json.Marshal(&AddressResolutionRequest{SenderHandle: "bob@example.com", Dt: "2020-01-01T00:00:00Z"})
+
+	resp, err := http.Post(srv.URL+"/address/alice@example.com", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("request failed: %s", err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got: %d", resp.StatusCode)
+	}
+
+	var res AddressResolutionResponse
+	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatalf("failed to decode response: %s", err.Error())
+	}
+
+	if res.Output != "76a914...88ac" {
+		t.Errorf("expected the configured output script, got: %s", res.Output)
+	}
+}
+
+// TestMemoryServiceProvider_ConcurrentP2P exercises CreateP2PDestinationResponse
+// and RecordTransaction concurrently - run with `go test -race` to catch data
+// races on refCounter/references/transactions.
+func TestMemoryServiceProvider_ConcurrentP2P(t *testing.T) {
+	provider := NewMemoryServiceProvider()
+	provider.OutputScripts["alice@example.com"] = "76a914...88ac"
+
+	const workers = 25
+
+	var wg sync.WaitGroup
+	refs := make([]string, workers)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			dest, err := provider.CreateP2PDestinationResponse("alice", "example.com", 1000)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err.Error())
+				return
+			}
+			refs[i] = dest.Reference
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool, workers)
+	for _, ref := range refs {
+		if len(ref) == 0 {
+			t.Fatal("expected every worker to get a reference")
+		}
+		if seen[ref] {
+			t.Fatalf("expected unique references, got duplicate: %s", ref)
+		}
+		seen[ref] = true
+	}
+
+	wg = sync.WaitGroup{}
+	for _, ref := range refs {
+		wg.Add(1)
+		go func(ref string) {
+			defer wg.Done()
+			if _, err := provider.RecordTransaction("alice", "example.com", &P2PTransactionRequest{Hex: "deadbeef", Reference: ref}); err != nil {
+				t.Errorf("unexpected error recording transaction: %s", err.Error())
+			}
+		}(ref)
+	}
+	wg.Wait()
+
+	// A reference must not be replayable once recorded
+	if _, err := provider.RecordTransaction("alice", "example.com", &P2PTransactionRequest{Hex: "deadbeef", Reference: refs[0]}); err == nil {
+		t.Error("expected an error when replaying an already-used reference")
+	}
+}
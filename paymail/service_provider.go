@@ -0,0 +1,115 @@
+package paymail
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ServiceProvider is implemented by anything that can answer paymail requests on
+// behalf of a domain. The serve command wires an HTTP server's routes to one of
+// these, so users can plug in their own backend (database, wallet, etc.) instead
+// of using the bundled in-memory reference implementation.
+type ServiceProvider interface {
+
+	// GetPKI returns the public key information for a given alias@domain
+	GetPKI(alias, domain string) (*PKIResponse, error)
+
+	// CreateAddressResolutionResponse resolves a basic address resolution (or
+	// payment destination) request into an output script + address
+	CreateAddressResolutionResponse(alias, domain string, senderRequest *AddressResolutionRequest) (*AddressResolutionResponse, error)
+
+	// CreateP2PDestinationResponse returns one or more outputs (plus a reference)
+	// for a given amount of satoshis, per the P2P Payment Destination capability
+	CreateP2PDestinationResponse(alias, domain string, satoshis uint64) (*P2PPaymentDestinationResponse, error)
+
+	// RecordTransaction accepts a raw transaction + reference + metadata submitted
+	// via the P2P Transaction capability and returns the accepted txid
+	RecordTransaction(alias, domain string, tx *P2PTransactionRequest) (*P2PTransactionResponse, error)
+}
+
+// MemoryServiceProvider is an in-memory reference implementation of ServiceProvider,
+// intended for local testing of the serve command. It is not safe for production
+// use since nothing is persisted to disk.
+type MemoryServiceProvider struct {
+	// Identities maps "alias@domain" to a static pubkey
+	Identities map[string]string
+
+	// OutputScripts maps "alias@domain" to a static hex output script to hand out
+	OutputScripts map[string]string
+
+	mu           sync.Mutex
+	references   map[string]uint64
+	transactions map[string]*P2PTransactionRequest
+	refCounter   uint64
+}
+
+// NewMemoryServiceProvider returns an empty, ready-to-use MemoryServiceProvider
+func NewMemoryServiceProvider() *MemoryServiceProvider {
+	return &MemoryServiceProvider{
+		Identities:    make(map[string]string),
+		OutputScripts: make(map[string]string),
+		references:    make(map[string]uint64),
+		transactions:  make(map[string]*P2PTransactionRequest),
+	}
+}
+
+// GetPKI returns the configured pubkey for alias@domain, or an error if unknown
+func (m *MemoryServiceProvider) GetPKI(alias, domain string) (*PKIResponse, error) {
+	pubKey, ok := m.Identities[alias+"@"+domain]
+	if !ok {
+		return nil, fmt.Errorf("no identity configured for: %s@%s", alias, domain)
+	}
+
+	return &PKIResponse{
+		BsvAlias: "1.0",
+		Handle:   alias + "@" + domain,
+		PubKey:   pubKey,
+	}, nil
+}
+
+// CreateAddressResolutionResponse returns the configured static output script for alias@domain
+func (m *MemoryServiceProvider) CreateAddressResolutionResponse(alias, domain string, _ *AddressResolutionRequest) (*AddressResolutionResponse, error) {
+	output, ok := m.OutputScripts[alias+"@"+domain]
+	if !ok {
+		return nil, fmt.Errorf("no output script configured for: %s@%s", alias, domain)
+	}
+
+	return &AddressResolutionResponse{Output: output}, nil
+}
+
+// CreateP2PDestinationResponse hands back the configured static output script wrapped
+// in a single P2POutput, along with a freshly generated reference
+func (m *MemoryServiceProvider) CreateP2PDestinationResponse(alias, domain string, satoshis uint64) (*P2PPaymentDestinationResponse, error) {
+	output, ok := m.OutputScripts[alias+"@"+domain]
+	if !ok {
+		return nil, fmt.Errorf("no output script configured for: %s@%s", alias, domain)
+	}
+
+	m.mu.Lock()
+	m.refCounter++
+	reference := fmt.Sprintf("%s-%d", alias, m.refCounter)
+	m.references[reference] = satoshis
+	m.mu.Unlock()
+
+	return &P2PPaymentDestinationResponse{
+		Outputs:   []*P2POutput{{Script: output, Satoshis: satoshis}},
+		Reference: reference,
+	}, nil
+}
+
+// RecordTransaction validates the reference was issued by this provider and stores
+// the submitted transaction in memory. The reference is consumed so it cannot be
+// replayed against a second transaction.
+func (m *MemoryServiceProvider) RecordTransaction(alias, domain string, tx *P2PTransactionRequest) (*P2PTransactionResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.references[tx.Reference]; !ok {
+		return nil, fmt.Errorf("unknown or already-used reference: %s", tx.Reference)
+	}
+	delete(m.references, tx.Reference)
+
+	m.transactions[tx.Reference] = tx
+
+	return &P2PTransactionResponse{TxID: tx.Reference, Note: "recorded in-memory, not broadcast"}, nil
+}
@@ -0,0 +1,73 @@
+package paymail
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/bitcoinschema/go-bitcoin"
+	"github.com/bitcoinsv/bsvutil"
+)
+
+// SignAddressResolutionRequest signs an AddressResolutionRequest per the sender
+// validation spec (bsvalias 04-02: Sender Validation).
+//
+// The canonical message is built from SenderHandle + Amount + Dt + Purpose (in that
+// order, concatenated with no separator), then signed using the Bitcoin Signed
+// Message format (double-SHA256 of the `\x18Bitcoin Signed Message:\n` prefix plus
+// a varint-encoded message length) with a compact recoverable ECDSA signature over
+// secp256k1. The result is returned base64-encoded, ready to set on Signature.
+//
+// privateKey accepts either a WIF-encoded key or a 64-character hex-encoded key. A
+// zero Amount is omitted from the message, matching the omitempty JSON encoding a
+// receiver reconstructing the message from the request body will see.
+//
+// Read more at: https://bsvalias.org/04-02-sender-validation.html
+func SignAddressResolutionRequest(req *AddressResolutionRequest, privateKey string) (string, error) {
+	if req == nil {
+		return "", fmt.Errorf("request cannot be nil")
+	} else if len(privateKey) == 0 {
+		return "", fmt.Errorf("missing private key (wif or hex) to sign with")
+	}
+
+	keyHex, compressed, err := privateKeyHex(privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	amount := ""
+	if req.Amount > 0 {
+		amount = fmt.Sprintf("%d", req.Amount)
+	}
+	message := req.SenderHandle + amount + req.Dt + req.Purpose
+
+	var signature string
+	if signature, err = bitcoin.SignMessage(keyHex, message, compressed); err != nil {
+		return "", fmt.Errorf("failed to sign address resolution request: %w", err)
+	}
+
+	return signature, nil
+}
+
+// privateKeyHex normalizes a WIF or hex-encoded private key into the hex encoding
+// that bitcoin.SignMessage expects (it does not understand WIF on its own), and
+// reports whether the resulting recoverable signature should reference a
+// compressed pubkey.
+//
+// A WIF carries its own compression flag, which is preserved here since
+// bitcoin.WifToPrivateKeyString discards it. A bare hex key carries no such flag,
+// so it is treated as compressed - the common case for modern keys.
+func privateKeyHex(privateKey string) (keyHex string, compressed bool, err error) {
+	// 64 hex characters (32 bytes) - assume it's already a hex-encoded key
+	if len(privateKey) == 64 {
+		if _, hexErr := hex.DecodeString(privateKey); hexErr == nil {
+			return privateKey, true, nil
+		}
+	}
+
+	wif, err := bsvutil.DecodeWIF(privateKey)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid private key: expected a WIF or 64-character hex-encoded key: %w", err)
+	}
+
+	return hex.EncodeToString(wif.PrivKey.Serialize()), wif.CompressPubKey, nil
+}
@@ -0,0 +1,134 @@
+package paymail
+
+import (
+	"testing"
+
+	"github.com/bitcoinschema/go-bitcoin"
+)
+
+// testHexPrivateKey / testWifPrivateKey / testWifPrivateKeyCompressed are all
+// encodings of the same key: testWifPrivateKey is uncompressed (a "5"-prefix
+// WIF), testWifPrivateKeyCompressed is compressed (a "K"/"L"-prefix WIF) -
+// bare hex carries no compression flag, so it's treated as compressed.
+const (
+	testHexPrivateKey           = "ed8a60565c8255ed2215353d886baf9ea0ce3547c5ce4da5b6ebbdac53f98f46"
+	testWifPrivateKey           = "5KcuEcomtW1CYgaNDV2w4VUSBnkHGFnUA89jjE6HNVYqrTCvijJ"
+	testWifPrivateKeyCompressed = "L5BTZ73T8K1sxqgGe6jXcQg6Acv2nLFgsGZZbNnKFs1YcwaF2SnD"
+
+	// testCompressedWifPrivateKey is a different key, encoded as a compressed
+	// ("K"/"L"-prefix) WIF - the common case for modern keys
+	testCompressedWifPrivateKey = "L13yyscwMVVPtzWUieY2GycCMbz47d6xwc6tL1etgBs1m8kgXGVe"
+)
+
+func testAddressResolutionRequest() *AddressResolutionRequest {
+	return &AddressResolutionRequest{
+		Amount:       1000,
+		Dt:           "2020-04-09T10:00:00.000Z",
+		Purpose:      "test",
+		SenderHandle: "sender@example.com",
+	}
+}
+
+func TestSignAddressResolutionRequest_NilRequest(t *testing.T) {
+	if _, err := SignAddressResolutionRequest(nil, testHexPrivateKey); err == nil {
+		t.Error("expected an error for a nil request")
+	}
+}
+
+func TestSignAddressResolutionRequest_MissingKey(t *testing.T) {
+	if _, err := SignAddressResolutionRequest(testAddressResolutionRequest(), ""); err == nil {
+		t.Error("expected an error for a missing private key")
+	}
+}
+
+func TestSignAddressResolutionRequest_InvalidKey(t *testing.T) {
+	if _, err := SignAddressResolutionRequest(testAddressResolutionRequest(), "not-a-valid-key"); err == nil {
+		t.Error("expected an error for an invalid private key")
+	}
+}
+
+func TestSignAddressResolutionRequest_HexAndCompressedWifAgree(t *testing.T) {
+	hexSig, err := SignAddressResolutionRequest(testAddressResolutionRequest(), testHexPrivateKey)
+	if err != nil {
+		t.Fatalf("failed to sign with hex key: %s", err.Error())
+	}
+
+	wifSig, err := SignAddressResolutionRequest(testAddressResolutionRequest(), testWifPrivateKeyCompressed)
+	if err != nil {
+		t.Fatalf("failed to sign with wif key: %s", err.Error())
+	}
+
+	if len(hexSig) == 0 || len(wifSig) == 0 {
+		t.Error("expected non-empty signatures")
+	}
+
+	// A bare hex key is treated as compressed, so it must agree with the same
+	// key's compressed WIF encoding
+	if hexSig != wifSig {
+		t.Errorf("expected hex and compressed-wif signatures to match, got: %s vs %s", hexSig, wifSig)
+	}
+}
+
+func TestSignAddressResolutionRequest_UncompressedWifDiffersFromHex(t *testing.T) {
+	hexSig, err := SignAddressResolutionRequest(testAddressResolutionRequest(), testHexPrivateKey)
+	if err != nil {
+		t.Fatalf("failed to sign with hex key: %s", err.Error())
+	}
+
+	wifSig, err := SignAddressResolutionRequest(testAddressResolutionRequest(), testWifPrivateKey)
+	if err != nil {
+		t.Fatalf("failed to sign with wif key: %s", err.Error())
+	}
+
+	// The uncompressed WIF encodes the same scalar but a different compression
+	// bit than the hex key (treated as compressed), so the recoverable
+	// signatures must legitimately differ
+	if hexSig == wifSig {
+		t.Error("expected an uncompressed wif's signature to differ from the (compressed) hex key's signature")
+	}
+}
+
+func TestSignAddressResolutionRequest_CompressedWifPreservesCompressionBit(t *testing.T) {
+	sig, err := SignAddressResolutionRequest(testAddressResolutionRequest(), testCompressedWifPrivateKey)
+	if err != nil {
+		t.Fatalf("failed to sign with compressed wif key: %s", err.Error())
+	}
+
+	req := testAddressResolutionRequest()
+	message := req.SenderHandle + "1000" + req.Dt + req.Purpose
+
+	_, wasCompressed, err := bitcoin.PubKeyFromSignature(sig, message)
+	if err != nil {
+		t.Fatalf("failed to recover pubkey from signature: %s", err.Error())
+	}
+
+	// A "K"/"L"-prefix WIF is compressed - the recoverable signature must say so,
+	// otherwise a receiver recovers the wrong (uncompressed) address
+	if !wasCompressed {
+		t.Error("expected the signature to reference a compressed pubkey for a compressed WIF")
+	}
+}
+
+func TestSignAddressResolutionRequest_ZeroAmountOmittedFromMessage(t *testing.T) {
+	req := testAddressResolutionRequest()
+	req.Amount = 0
+
+	sig, err := SignAddressResolutionRequest(req, testHexPrivateKey)
+	if err != nil {
+		t.Fatalf("failed to sign: %s", err.Error())
+	}
+
+	// The message a receiver reconstructs from the (omitempty) JSON body has no
+	// "amount" at all when it's zero, so the signed message must not contain a
+	// literal "0" either
+	message := req.SenderHandle + req.Dt + req.Purpose
+
+	address, err := bitcoin.GetAddressFromPrivateKeyString(testHexPrivateKey, true)
+	if err != nil {
+		t.Fatalf("failed to derive address: %s", err.Error())
+	}
+
+	if err = bitcoin.VerifyMessage(address, sig, message); err != nil {
+		t.Errorf("expected signature to verify against the zero-amount-omitted message: %s", err.Error())
+	}
+}
@@ -0,0 +1,33 @@
+package paymail
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+)
+
+// CheckSSL dials host:port with TLS, using the system root CAs and SNI set to
+// host, and verifies that the server presents a valid certificate chain. The
+// paymail spec requires all capability URLs to be served over HTTPS with a
+// valid certificate.
+//
+// Read more at: http://bsvalias.org/02-02-http-transport.html
+func CheckSSL(host string, port int) (bool, error) {
+	address := net.JoinHostPort(host, fmt.Sprintf("%d", port))
+
+	conn, err := tls.Dial("tcp", address, &tls.Config{ServerName: host})
+	if err != nil {
+		return false, fmt.Errorf("tls dial failed: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	state := conn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return false, fmt.Errorf("no peer certificates presented by: %s", host)
+	}
+
+	// tls.Dial already verifies the chain against system roots and the SNI
+	// hostname unless InsecureSkipVerify is set, so a successful handshake
+	// here means the chain is valid.
+	return true, nil
+}
@@ -0,0 +1,34 @@
+package paymail
+
+import (
+	"net"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestCheckSSL_ConnectionFailure(t *testing.T) {
+	if ok, err := CheckSSL("127.0.0.1", 1); ok || err == nil {
+		t.Error("expected CheckSSL to fail against a closed port")
+	}
+}
+
+func TestCheckSSL_SelfSignedCertificateRejected(t *testing.T) {
+	srv := httptest.NewTLSServer(nil)
+	defer srv.Close()
+
+	host, portStr, err := net.SplitHostPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to parse test server address: %s", err.Error())
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatalf("failed to parse test server port: %s", err.Error())
+	}
+
+	// httptest.NewTLSServer presents a self-signed cert that isn't trusted by
+	// the system roots CheckSSL validates against, so the chain must be rejected
+	if ok, sslErr := CheckSSL(host, port); ok || sslErr == nil {
+		t.Error("expected CheckSSL to reject a self-signed certificate")
+	}
+}